@@ -0,0 +1,101 @@
+package limits
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestBufferedBodyLimiterInMemory(t *testing.T) {
+	router := gin.New()
+	router.Use(BufferedBodyLimiter(1024, 4096, t.TempDir()))
+	router.POST("/test", func(c *gin.Context) {
+		if len(c.Errors) > 0 {
+			return
+		}
+		data, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(data))
+	})
+
+	resp := performRequest("/test", "hello world", router)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected status %v, got %v", http.StatusOK, resp.Code)
+	}
+	if resp.Body.String() != "hello world" {
+		t.Fatalf("expected body %q, got %q", "hello world", resp.Body.String())
+	}
+}
+
+func TestBufferedBodyLimiterSpillsToDisk(t *testing.T) {
+	router := gin.New()
+	router.Use(BufferedBodyLimiter(8, 4096, t.TempDir()))
+	router.POST("/test", func(c *gin.Context) {
+		if len(c.Errors) > 0 {
+			return
+		}
+		data, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(data))
+	})
+
+	body := "this body is longer than the in-memory limit"
+	resp := performRequest("/test", body, router)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected status %v, got %v", http.StatusOK, resp.Code)
+	}
+	if resp.Body.String() != body {
+		t.Fatalf("expected body %q, got %q", body, resp.Body.String())
+	}
+}
+
+func TestBufferedBodyLimiterOverMax(t *testing.T) {
+	router := gin.New()
+	router.Use(BufferedBodyLimiter(8, 16, t.TempDir()))
+	router.POST("/test", func(c *gin.Context) {
+		if len(c.Errors) > 0 {
+			return
+		}
+		_, _ = io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, "OK")
+	})
+
+	resp := performRequest("/test", "this body is far longer than maxLimit allows", router)
+	if resp.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected status %v, got %v", http.StatusRequestEntityTooLarge, resp.Code)
+	}
+}
+
+func TestBufferedBodySeek(t *testing.T) {
+	router := gin.New()
+	router.Use(BufferedBodyLimiter(4, 4096, t.TempDir()))
+	router.POST("/test", func(c *gin.Context) {
+		if len(c.Errors) > 0 {
+			return
+		}
+		first, _ := io.ReadAll(c.Request.Body)
+
+		body, ok := BufferedBody(c)
+		if !ok {
+			c.String(http.StatusInternalServerError, "no buffered body")
+			return
+		}
+		if _, err := body.Seek(0, io.SeekStart); err != nil {
+			c.String(http.StatusInternalServerError, "seek failed: %v", err)
+			return
+		}
+		second, _ := io.ReadAll(c.Request.Body)
+
+		if !bytes.Equal(first, second) {
+			c.String(http.StatusInternalServerError, "re-read mismatch")
+			return
+		}
+		c.String(http.StatusOK, "OK")
+	})
+
+	resp := performRequest("/test", "re-readable payload", router)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected status %v, got %v: %s", http.StatusOK, resp.Code, resp.Body.String())
+	}
+}
@@ -0,0 +1,20 @@
+package limits
+
+// RequestTooLargeError is pushed into the context via ctx.Error() by
+// RequestSizeLimiter and RequestSizeLimiterWithConfig when a request exceeds
+// its configured limit. Downstream error middleware can inspect Limit, Read,
+// and Route to format a problem-details response instead of relying on the
+// fixed default body.
+type RequestTooLargeError struct {
+	// Limit is the configured limit that was exceeded.
+	Limit int64
+	// Read is the number of bytes read from the body before rejection.
+	Read int64
+	// Route is "METHOD fullpath" for the request, in the same form used
+	// by Config.RouteLimits.
+	Route string
+}
+
+func (e *RequestTooLargeError) Error() string {
+	return "HTTP request too large"
+}
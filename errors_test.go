@@ -0,0 +1,38 @@
+package limits
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestSizeLimiterPushesRequestTooLargeError(t *testing.T) {
+	router := gin.New()
+	router.Use(RequestSizeLimiter(5))
+
+	var pushed error
+	router.POST("/test", func(c *gin.Context) {
+		_, _ = io.ReadAll(c.Request.Body)
+		if len(c.Errors) > 0 {
+			pushed = c.Errors[0].Err
+			return
+		}
+		c.String(http.StatusOK, "OK")
+	})
+
+	performRequest("/test", "way too long", router)
+
+	var tooLarge *RequestTooLargeError
+	if !errors.As(pushed, &tooLarge) {
+		t.Fatalf("expected a *RequestTooLargeError, got %T", pushed)
+	}
+	if tooLarge.Limit != 5 {
+		t.Fatalf("expected Limit 5, got %d", tooLarge.Limit)
+	}
+	if tooLarge.Route != "POST /test" {
+		t.Fatalf("expected Route %q, got %q", "POST /test", tooLarge.Route)
+	}
+}
@@ -0,0 +1,109 @@
+package limits
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Pre-defined error to avoid repeated allocations
+var errHeaderTooLarge = errors.New("HTTP request header fields too large")
+
+// HeaderLimits configures HeaderSizeLimiterWithConfig and the header portion
+// of Limits. A zero value in any field disables that particular check.
+type HeaderLimits struct {
+	// MaxBytes caps the combined size, in bytes, of every header name,
+	// value, and ": "/"\r\n" separator on the request.
+	MaxBytes int
+
+	// MaxValueBytes caps the length of any single header value.
+	MaxValueBytes int
+
+	// MaxCount caps the total number of headers on the request.
+	MaxCount int
+}
+
+// headerTooLarge mirrors maxBytesReader.tooLarge: it records the error on the
+// context, marks the connection for closing, and aborts with a JSON body.
+func headerTooLarge(ctx *gin.Context) {
+	ctx.Error(errHeaderTooLarge)
+	ctx.Header("Connection", "close") // Proper header capitalization
+	ctx.AbortWithStatusJSON(http.StatusRequestHeaderFieldsTooLarge, gin.H{
+		"error": "request header fields too large",
+	})
+}
+
+// HeaderSizeLimiter returns a middleware that rejects requests whose total
+// header size exceeds maxHeaderBytes with 431 Request Header Fields Too
+// Large, using the same error/abort semantics as RequestSizeLimiter.
+//
+// This only protects the handler chain: an attacker can still make the
+// server buffer an oversized header block while it's being parsed, so pair
+// this with http.Server.MaxHeaderBytes at the server level.
+func HeaderSizeLimiter(maxHeaderBytes int) gin.HandlerFunc {
+	return HeaderSizeLimiterWithConfig(HeaderLimits{MaxBytes: maxHeaderBytes})
+}
+
+// HeaderSizeLimiterWithConfig is like HeaderSizeLimiter, but also allows
+// capping individual header value length and the total number of headers.
+func HeaderSizeLimiterWithConfig(limits HeaderLimits) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if checkHeaderLimits(ctx, limits) {
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// checkHeaderLimits enforces limits against ctx.Request.Header, aborting
+// with 431 and returning true if a limit was exceeded. It does not call
+// ctx.Next, so it can be composed into a larger middleware (see Limits)
+// without that middleware's single ctx.Next call running the rest of the
+// handler chain early.
+func checkHeaderLimits(ctx *gin.Context, limits HeaderLimits) (rejected bool) {
+	total := 0
+	count := 0
+	for name, values := range ctx.Request.Header {
+		for _, value := range values {
+			count++
+			if limits.MaxValueBytes > 0 && len(value) > limits.MaxValueBytes {
+				headerTooLarge(ctx)
+				return true
+			}
+			// name + ": " + value + "\r\n"
+			total += len(name) + len(value) + 4
+		}
+	}
+
+	if limits.MaxCount > 0 && count > limits.MaxCount {
+		headerTooLarge(ctx)
+		return true
+	}
+	if limits.MaxBytes > 0 && total > limits.MaxBytes {
+		headerTooLarge(ctx)
+		return true
+	}
+	return false
+}
+
+// LimitsConfig configures Limits, combining a request body Config with
+// HeaderLimits so both can be installed with a single middleware.
+type LimitsConfig struct {
+	Body   Config
+	Header HeaderLimits
+}
+
+// Limits returns a middleware combining HeaderSizeLimiterWithConfig and
+// RequestSizeLimiterWithConfig under a single LimitsConfig, so one r.Use()
+// installs both header and body size policy. Headers are checked first,
+// since RequestSizeLimiter never sees bytes spent on headers.
+func Limits(cfg LimitsConfig) gin.HandlerFunc {
+	bodyLimiter := RequestSizeLimiterWithConfig(cfg.Body)
+	return func(ctx *gin.Context) {
+		if checkHeaderLimits(ctx, cfg.Header) {
+			return
+		}
+		bodyLimiter(ctx)
+	}
+}
@@ -0,0 +1,145 @@
+package limits
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestHeaderSizeLimiterOK(t *testing.T) {
+	router := gin.New()
+	router.Use(HeaderSizeLimiter(1024))
+	router.GET("/test_ok", func(c *gin.Context) {
+		if len(c.Errors) > 0 {
+			return
+		}
+		c.String(http.StatusOK, "OK")
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/test_ok", nil)
+	r.Header.Set("X-Small", "value")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %v, got %v", http.StatusOK, w.Code)
+	}
+}
+
+func TestHeaderSizeLimiterOver(t *testing.T) {
+	router := gin.New()
+	router.Use(HeaderSizeLimiter(64))
+	router.GET("/test_large", func(c *gin.Context) {
+		if len(c.Errors) > 0 {
+			return
+		}
+		c.String(http.StatusOK, "OK")
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/test_large", nil)
+	r.Header.Set("X-Big", strings.Repeat("a", 1024))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusRequestHeaderFieldsTooLarge {
+		t.Fatalf("expected status %v, got %v", http.StatusRequestHeaderFieldsTooLarge, w.Code)
+	}
+	if w.Header().Get("Connection") != "close" {
+		t.Fatalf("expected Connection header to be 'close', got '%s'", w.Header().Get("Connection"))
+	}
+}
+
+func TestHeaderSizeLimiterWithConfigMaxCount(t *testing.T) {
+	router := gin.New()
+	router.Use(HeaderSizeLimiterWithConfig(HeaderLimits{MaxCount: 2}))
+	router.GET("/test_count", func(c *gin.Context) {
+		if len(c.Errors) > 0 {
+			return
+		}
+		c.String(http.StatusOK, "OK")
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/test_count", nil)
+	r.Header.Set("X-One", "a")
+	r.Header.Set("X-Two", "b")
+	r.Header.Set("X-Three", "c")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusRequestHeaderFieldsTooLarge {
+		t.Fatalf("expected status %v, got %v", http.StatusRequestHeaderFieldsTooLarge, w.Code)
+	}
+}
+
+func TestHeaderSizeLimiterWithConfigMaxValueBytes(t *testing.T) {
+	router := gin.New()
+	router.Use(HeaderSizeLimiterWithConfig(HeaderLimits{MaxValueBytes: 8}))
+	router.GET("/test_value", func(c *gin.Context) {
+		if len(c.Errors) > 0 {
+			return
+		}
+		c.String(http.StatusOK, "OK")
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/test_value", nil)
+	r.Header.Set("X-Long", "way more than eight bytes")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusRequestHeaderFieldsTooLarge {
+		t.Fatalf("expected status %v, got %v", http.StatusRequestHeaderFieldsTooLarge, w.Code)
+	}
+}
+
+func TestLimitsBodyOverLimit(t *testing.T) {
+	router := gin.New()
+	router.Use(Limits(LimitsConfig{
+		Body:   Config{DefaultLimit: 5},
+		Header: HeaderLimits{MaxBytes: 1024},
+	}))
+	router.POST("/test_limits_body", func(c *gin.Context) {
+		_, _ = io.ReadAll(c.Request.Body)
+		if len(c.Errors) > 0 {
+			return
+		}
+		c.String(http.StatusOK, "OK")
+	})
+
+	// Header is within limits, so the body limiter must still run and
+	// reject the oversized body.
+	r := httptest.NewRequest(http.MethodPost, "/test_limits_body", strings.NewReader("way too long"))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected status %v, got %v", http.StatusRequestEntityTooLarge, w.Code)
+	}
+}
+
+func TestLimitsHeaderAndBody(t *testing.T) {
+	router := gin.New()
+	router.Use(Limits(LimitsConfig{
+		Body:   Config{DefaultLimit: 10},
+		Header: HeaderLimits{MaxBytes: 64},
+	}))
+	router.POST("/test_limits", func(c *gin.Context) {
+		if len(c.Errors) > 0 {
+			return
+		}
+		c.String(http.StatusOK, "OK")
+	})
+
+	// Oversized header should be rejected before the body is even read.
+	r := httptest.NewRequest(http.MethodPost, "/test_limits", strings.NewReader("ok"))
+	r.Header.Set("X-Big", strings.Repeat("a", 1024))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusRequestHeaderFieldsTooLarge {
+		t.Fatalf("expected status %v, got %v", http.StatusRequestHeaderFieldsTooLarge, w.Code)
+	}
+}
@@ -0,0 +1,82 @@
+package limits
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metricsCollector holds the Prometheus instruments installed by
+// WithMetrics. Every method is nil-safe so callers don't need to guard calls
+// on whether metrics were configured.
+type metricsCollector struct {
+	rejections *prometheus.CounterVec
+	bytesRead  prometheus.Histogram
+	bodyBytes  prometheus.Histogram
+}
+
+// collectorsByReg caches the collector registered against each
+// Registerer so a registry shared by multiple limiter instances - e.g. one
+// process-wide registry with per-route size limits - only registers its
+// instruments once.
+var (
+	collectorsMu    sync.Mutex
+	collectorsByReg = map[prometheus.Registerer]*metricsCollector{}
+)
+
+func newMetricsCollector(reg prometheus.Registerer) *metricsCollector {
+	collectorsMu.Lock()
+	defer collectorsMu.Unlock()
+
+	if m, ok := collectorsByReg[reg]; ok {
+		return m
+	}
+
+	m := &metricsCollector{
+		rejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "size_limit_rejections_total",
+			Help: "Number of requests rejected for exceeding a configured size limit.",
+		}, []string{"route", "reason"}),
+		bytesRead: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "size_limit_bytes_read",
+			Help:    "Bytes read from the request body before it was accepted or rejected.",
+			Buckets: prometheus.ExponentialBuckets(64, 4, 10),
+		}),
+		bodyBytes: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "size_limit_body_bytes",
+			Help:    "Size, in bytes, of request bodies that completed within their limit.",
+			Buckets: prometheus.ExponentialBuckets(64, 4, 10),
+		}),
+	}
+	reg.MustRegister(m.rejections, m.bytesRead, m.bodyBytes)
+	collectorsByReg[reg] = m
+	return m
+}
+
+func (m *metricsCollector) observeRejection(err *RequestTooLargeError) {
+	if m == nil {
+		return
+	}
+	m.rejections.WithLabelValues(err.Route, "body_too_large").Inc()
+	m.bytesRead.Observe(float64(err.Read))
+}
+
+func (m *metricsCollector) observeAccepted(read int64) {
+	if m == nil {
+		return
+	}
+	m.bytesRead.Observe(float64(read))
+	m.bodyBytes.Observe(float64(read))
+}
+
+// WithMetrics registers Prometheus instruments on reg: size_limit_rejections_total,
+// a counter labeled by route and reason; size_limit_bytes_read, a histogram of
+// bytes read before acceptance or rejection; and size_limit_body_bytes, a
+// histogram of completed body sizes. A rejection is recorded when the limit
+// is exceeded; an accepted request is recorded when its body is closed, so a
+// request whose body is never closed is not recorded.
+func WithMetrics(reg prometheus.Registerer) Option {
+	return func(o *options) {
+		o.metrics = newMetricsCollector(reg)
+	}
+}
@@ -0,0 +1,44 @@
+package limits
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestRequestSizeLimiterWithMetricsRecordsRejection(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	router := gin.New()
+	router.Use(RequestSizeLimiter(5, WithMetrics(reg)))
+	router.POST("/test", func(c *gin.Context) {
+		_, _ = io.ReadAll(c.Request.Body)
+		if len(c.Errors) > 0 {
+			return
+		}
+		c.String(http.StatusOK, "OK")
+	})
+
+	performRequest("/test", "way too long", router)
+
+	metricFamilies, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("gather: %v", err)
+	}
+
+	var total float64
+	for _, mf := range metricFamilies {
+		if mf.GetName() != "size_limit_rejections_total" {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			total += m.GetCounter().GetValue()
+		}
+	}
+	if total != 1 {
+		t.Fatalf("expected 1 rejection recorded, got %v", total)
+	}
+}
@@ -0,0 +1,84 @@
+package limits
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Logger is the minimal logging interface accepted by WithLogger, satisfied
+// by *log.Logger from the standard library.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// options holds the behavior installed by Option values. The zero value,
+// filled in by newOptions, reproduces RequestSizeLimiter's original
+// hardcoded behavior.
+type options struct {
+	errorHandler func(ctx *gin.Context, limit int64)
+	statusCode   int
+	logger       Logger
+	skipper      func(ctx *gin.Context) bool
+	metrics      *metricsCollector
+}
+
+// Option configures RequestSizeLimiter and RequestSizeLimiterWithConfig.
+type Option func(*options)
+
+// WithErrorHandler replaces the default {"error":"request too large"} JSON
+// response. handler receives the limit that was exceeded and is responsible
+// for writing a response and aborting the context; WithStatusCode is ignored
+// when this is set.
+func WithErrorHandler(handler func(ctx *gin.Context, limit int64)) Option {
+	return func(o *options) {
+		o.errorHandler = handler
+	}
+}
+
+// WithStatusCode overrides the default 413 status code used by the built-in
+// error handler.
+func WithStatusCode(code int) Option {
+	return func(o *options) {
+		o.statusCode = code
+	}
+}
+
+// WithLogger records a message via logger whenever a request is rejected for
+// being too large.
+func WithLogger(logger Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithSkipper excludes requests matching skip from size limiting entirely,
+// e.g. authenticated admin routes or a specific Content-Type.
+func WithSkipper(skip func(ctx *gin.Context) bool) Option {
+	return func(o *options) {
+		o.skipper = skip
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{statusCode: http.StatusRequestEntityTooLarge}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// reject writes the rejection response for a request that exceeded limit.
+func (o *options) reject(ctx *gin.Context, limit int64) {
+	if o.logger != nil {
+		o.logger.Printf("limits: rejecting request over %d bytes on %s %s", limit, ctx.Request.Method, ctx.Request.URL.Path)
+	}
+	if o.errorHandler != nil {
+		o.errorHandler(ctx, limit)
+		return
+	}
+	ctx.Header("Connection", "close") // Proper header capitalization
+	ctx.AbortWithStatusJSON(o.statusCode, gin.H{
+		"error": "request too large",
+	})
+}
@@ -0,0 +1,98 @@
+package limits
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestSizeLimiterWithErrorHandler(t *testing.T) {
+	router := gin.New()
+	var gotLimit int64
+	router.Use(RequestSizeLimiter(5, WithErrorHandler(func(c *gin.Context, limit int64) {
+		gotLimit = limit
+		c.AbortWithStatusJSON(http.StatusTeapot, gin.H{"custom": true})
+	})))
+	router.POST("/test", func(c *gin.Context) {
+		_, _ = io.ReadAll(c.Request.Body)
+		if len(c.Errors) > 0 {
+			return
+		}
+		c.String(http.StatusOK, "OK")
+	})
+
+	resp := performRequest("/test", "way too long", router)
+
+	if resp.Code != http.StatusTeapot {
+		t.Fatalf("expected status %v, got %v", http.StatusTeapot, resp.Code)
+	}
+	if gotLimit != 5 {
+		t.Fatalf("expected error handler to receive limit 5, got %d", gotLimit)
+	}
+}
+
+func TestRequestSizeLimiterWithStatusCode(t *testing.T) {
+	router := gin.New()
+	router.Use(RequestSizeLimiter(5, WithStatusCode(http.StatusBadRequest)))
+	router.POST("/test", func(c *gin.Context) {
+		_, _ = io.ReadAll(c.Request.Body)
+		if len(c.Errors) > 0 {
+			return
+		}
+		c.String(http.StatusOK, "OK")
+	})
+
+	resp := performRequest("/test", "way too long", router)
+
+	if resp.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %v, got %v", http.StatusBadRequest, resp.Code)
+	}
+}
+
+func TestRequestSizeLimiterWithLogger(t *testing.T) {
+	var logged strings.Builder
+	logger := log.New(&logged, "", 0)
+
+	router := gin.New()
+	router.Use(RequestSizeLimiter(5, WithLogger(logger)))
+	router.POST("/test", func(c *gin.Context) {
+		_, _ = io.ReadAll(c.Request.Body)
+		if len(c.Errors) > 0 {
+			return
+		}
+		c.String(http.StatusOK, "OK")
+	})
+
+	performRequest("/test", "way too long", router)
+
+	if !strings.Contains(logged.String(), "limits:") {
+		t.Fatalf("expected logger to be called, got %q", logged.String())
+	}
+}
+
+func TestRequestSizeLimiterWithSkipper(t *testing.T) {
+	router := gin.New()
+	router.Use(RequestSizeLimiter(5, WithSkipper(func(c *gin.Context) bool {
+		return c.Request.Header.Get("X-Skip") == "true"
+	})))
+	router.POST("/test", func(c *gin.Context) {
+		if len(c.Errors) > 0 {
+			return
+		}
+		c.String(http.StatusOK, "OK")
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader("way too long to fit"))
+	r.Header.Set("X-Skip", "true")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected skipped request to bypass the limit, got status %v", w.Code)
+	}
+}
@@ -1,40 +1,42 @@
 package limits
 
 import (
-	"errors"
 	"io"
-	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
 
-// Pre-defined error to avoid repeated allocations
-var errRequestTooLarge = errors.New("HTTP request too large")
-
 type maxBytesReader struct {
 	ctx        *gin.Context
 	rdr        io.ReadCloser
 	remaining  int64
+	limit      int64
+	read       int64
+	opts       *options
 	wasAborted bool
 	sawEOF     bool
+	err        *RequestTooLargeError
 }
 
 func (mbr *maxBytesReader) tooLarge() (int, error) {
 	if !mbr.wasAborted {
 		mbr.wasAborted = true
-		mbr.ctx.Error(errRequestTooLarge)
-		mbr.ctx.Header("Connection", "close") // Proper header capitalization
-		mbr.ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
-			"error": "request too large",
-		})
+		mbr.err = &RequestTooLargeError{
+			Limit: mbr.limit,
+			Read:  mbr.read,
+			Route: mbr.ctx.Request.Method + " " + mbr.ctx.FullPath(),
+		}
+		mbr.ctx.Error(mbr.err)
+		mbr.opts.reject(mbr.ctx, mbr.limit)
+		mbr.opts.metrics.observeRejection(mbr.err)
 	}
-	return 0, errRequestTooLarge
+	return 0, mbr.err
 }
 
 func (mbr *maxBytesReader) Read(p []byte) (int, error) {
 	// Early return if already aborted to avoid unnecessary work
 	if mbr.wasAborted {
-		return 0, errRequestTooLarge
+		return 0, mbr.err
 	}
 
 	toRead := mbr.remaining
@@ -68,6 +70,7 @@ func (mbr *maxBytesReader) Read(p []byte) (int, error) {
 		return 0, err
 	}
 
+	mbr.read += int64(n)
 	mbr.remaining -= int64(n)
 	if mbr.remaining < 0 {
 		mbr.remaining = 0
@@ -76,21 +79,79 @@ func (mbr *maxBytesReader) Read(p []byte) (int, error) {
 }
 
 func (mbr *maxBytesReader) Close() error {
+	if !mbr.wasAborted {
+		mbr.opts.metrics.observeAccepted(mbr.read)
+	}
 	return mbr.rdr.Close()
 }
 
-// RequestSizeLimiter returns a middleware that limits the size of request
+// RequestSizeLimiter returns a middleware that limits the size of request.
 // When a request is over the limit, the following will happen:
 // * Error will be added to the context
 // * Connection: close header will be set
 // * Error 413 will be sent to the client (http.StatusRequestEntityTooLarge)
 // * Current context will be aborted
-func RequestSizeLimiter(limit int64) gin.HandlerFunc {
+//
+// Any of WithErrorHandler, WithStatusCode, WithLogger, and WithSkipper may be
+// passed in opts to customize this behavior.
+func RequestSizeLimiter(limit int64, opts ...Option) gin.HandlerFunc {
+	return RequestSizeLimiterWithConfig(Config{DefaultLimit: limit}, opts...)
+}
+
+// Config configures RequestSizeLimiterWithConfig with a default request body
+// limit plus optional, more specific overrides.
+type Config struct {
+	// DefaultLimit is used whenever neither RouteLimits nor
+	// ContentTypeLimits has a match for the current request.
+	DefaultLimit int64
+
+	// ContentTypeLimits overrides DefaultLimit based on the incoming
+	// request's Content-Type, ignoring parameters such as charset or
+	// boundary (e.g. "application/json", "multipart/form-data").
+	ContentTypeLimits map[string]int64
+
+	// RouteLimits overrides both DefaultLimit and ContentTypeLimits for a
+	// specific route, keyed by "METHOD fullpath" using the path pattern
+	// registered with the router (e.g. "POST /upload").
+	RouteLimits map[string]int64
+}
+
+// limitFor returns the effective body size limit for ctx: a RouteLimits
+// match wins, then a ContentTypeLimits match, then DefaultLimit.
+func (cfg Config) limitFor(ctx *gin.Context) int64 {
+	if cfg.RouteLimits != nil {
+		if limit, ok := cfg.RouteLimits[ctx.Request.Method+" "+ctx.FullPath()]; ok {
+			return limit
+		}
+	}
+	if cfg.ContentTypeLimits != nil {
+		if limit, ok := cfg.ContentTypeLimits[ctx.ContentType()]; ok {
+			return limit
+		}
+	}
+	return cfg.DefaultLimit
+}
+
+// RequestSizeLimiterWithConfig is like RequestSizeLimiter, except the limit
+// applied to a given request is chosen from cfg instead of being fixed. This
+// lets a single r.Use() install a default limit while raising or lowering it
+// for specific content types or routes (e.g. a higher limit for
+// multipart/form-data uploads on one route).
+func RequestSizeLimiterWithConfig(cfg Config, opts ...Option) gin.HandlerFunc {
+	o := newOptions(opts)
 	return func(ctx *gin.Context) {
+		if o.skipper != nil && o.skipper(ctx) {
+			ctx.Next()
+			return
+		}
+
+		limit := cfg.limitFor(ctx)
 		ctx.Request.Body = &maxBytesReader{
 			ctx:       ctx,
 			rdr:       ctx.Request.Body,
 			remaining: limit,
+			limit:     limit,
+			opts:      o,
 			// wasAborted and sawEOF default to false, no need to specify
 		}
 		ctx.Next()
@@ -200,6 +200,69 @@ func TestMaxBytesReaderClose(t *testing.T) {
 	}
 }
 
+// Test that a route-specific override wins over the default limit
+func TestRequestSizeLimiterWithConfigRouteOverride(t *testing.T) {
+	router := gin.New()
+	router.Use(RequestSizeLimiterWithConfig(Config{
+		DefaultLimit: 5,
+		RouteLimits: map[string]int64{
+			"POST /upload": 20,
+		},
+	}))
+	router.POST("/upload", func(c *gin.Context) {
+		if len(c.Errors) > 0 {
+			return
+		}
+		_, _ = io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, "OK")
+	})
+	router.POST("/other", func(c *gin.Context) {
+		if len(c.Errors) > 0 {
+			return
+		}
+		_, _ = io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, "OK")
+	})
+
+	resp := performRequest("/upload", "123456789012345", router)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected route override to allow request, got status %v", resp.Code)
+	}
+
+	resp = performRequest("/other", "123456789012345", router)
+	if resp.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected default limit to apply, got status %v", resp.Code)
+	}
+}
+
+// Test that a content-type override is used when no route override matches
+func TestRequestSizeLimiterWithConfigContentTypeOverride(t *testing.T) {
+	router := gin.New()
+	router.Use(RequestSizeLimiterWithConfig(Config{
+		DefaultLimit: 5,
+		ContentTypeLimits: map[string]int64{
+			"application/json": 100,
+		},
+	}))
+	router.POST("/test", func(c *gin.Context) {
+		if len(c.Errors) > 0 {
+			return
+		}
+		_, _ = io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, "OK")
+	})
+
+	buf := bytes.NewBufferString(`{"key":"a value long enough to exceed the default limit"}`)
+	r := httptest.NewRequest(http.MethodPost, "/test", buf)
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected content-type override to allow request, got status %v", w.Code)
+	}
+}
+
 func performRequest(target, body string, router *gin.Engine) *httptest.ResponseRecorder {
 	buf := bytes.NewBufferString(body)
 	r := httptest.NewRequest(http.MethodPost, target, buf)
@@ -0,0 +1,127 @@
+package limits
+
+import (
+	"container/list"
+	"io"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// throttledReader wraps an io.ReadCloser with a token-bucket rate limiter,
+// analogous to maxBytesReader but smoothing throughput instead of capping
+// total size.
+type throttledReader struct {
+	ctx     *gin.Context
+	rdr     io.ReadCloser
+	limiter *rate.Limiter
+}
+
+func (t *throttledReader) Read(p []byte) (int, error) {
+	// rate.WaitN rejects any n larger than the bucket's burst size instead of
+	// waiting for it, so the read must be capped to burst before it reaches
+	// the underlying reader - otherwise a caller-supplied buffer larger than
+	// burst (io.ReadAll grows its buffer well past typical burst sizes) would
+	// fail every call.
+	if burst := t.limiter.Burst(); int64(len(p)) > int64(burst) {
+		p = p[:burst]
+	}
+	n, err := t.rdr.Read(p)
+	if n > 0 {
+		if waitErr := t.limiter.WaitN(t.ctx.Request.Context(), n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
+
+func (t *throttledReader) Close() error {
+	return t.rdr.Close()
+}
+
+// ThrottledBodyReader returns a middleware that wraps the request body in a
+// token-bucket rate limiter allowing bytesPerSecond sustained throughput with
+// bursts up to burst bytes. This keeps slow-loris-style uploads from tying up
+// a handler goroutine faster than allowed, while fast clients are smoothed to
+// the configured ingress rate. A disconnecting client unblocks immediately,
+// since WaitN honors ctx.Request.Context() cancellation.
+//
+// It composes with RequestSizeLimiter and RequestSizeLimiterWithConfig:
+// register both and each wraps whatever Request.Body the other left behind.
+func ThrottledBodyReader(bytesPerSecond, burst int64) gin.HandlerFunc {
+	limiter := rate.NewLimiter(rate.Limit(bytesPerSecond), int(burst))
+	return func(ctx *gin.Context) {
+		ctx.Request.Body = &throttledReader{
+			ctx:     ctx,
+			rdr:     ctx.Request.Body,
+			limiter: limiter,
+		}
+		ctx.Next()
+	}
+}
+
+// perIPEntry pairs an IP with its limiter for perIPLimiters' LRU list.
+type perIPEntry struct {
+	ip      string
+	limiter *rate.Limiter
+}
+
+// perIPLimiters is a bounded LRU cache of *rate.Limiter keyed by client IP,
+// so ThrottledBodyReaderPerIP doesn't grow a limiter per unique visitor
+// forever; the least recently used entry is evicted once max is exceeded.
+type perIPLimiters struct {
+	mu             sync.Mutex
+	max            int
+	bytesPerSecond int64
+	burst          int64
+	order          *list.List
+	elements       map[string]*list.Element
+}
+
+func newPerIPLimiters(bytesPerSecond, burst int64, max int) *perIPLimiters {
+	return &perIPLimiters{
+		max:            max,
+		bytesPerSecond: bytesPerSecond,
+		burst:          burst,
+		order:          list.New(),
+		elements:       make(map[string]*list.Element),
+	}
+}
+
+func (p *perIPLimiters) get(ip string) *rate.Limiter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if el, ok := p.elements[ip]; ok {
+		p.order.MoveToFront(el)
+		return el.Value.(*perIPEntry).limiter
+	}
+
+	limiter := rate.NewLimiter(rate.Limit(p.bytesPerSecond), int(p.burst))
+	p.elements[ip] = p.order.PushFront(&perIPEntry{ip: ip, limiter: limiter})
+
+	if p.order.Len() > p.max {
+		oldest := p.order.Back()
+		p.order.Remove(oldest)
+		delete(p.elements, oldest.Value.(*perIPEntry).ip)
+	}
+
+	return limiter
+}
+
+// ThrottledBodyReaderPerIP is like ThrottledBodyReader, but keys a separate
+// limiter per ctx.ClientIP() so one client's traffic can't consume another's
+// allowance. At most maxIPs limiters are kept at once; the rest are evicted
+// least-recently-used.
+func ThrottledBodyReaderPerIP(bytesPerSecond, burst int64, maxIPs int) gin.HandlerFunc {
+	limiters := newPerIPLimiters(bytesPerSecond, burst, maxIPs)
+	return func(ctx *gin.Context) {
+		ctx.Request.Body = &throttledReader{
+			ctx:     ctx,
+			rdr:     ctx.Request.Body,
+			limiter: limiters.get(ctx.ClientIP()),
+		}
+		ctx.Next()
+	}
+}
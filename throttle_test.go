@@ -0,0 +1,78 @@
+package limits
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestThrottledBodyReaderReadsFullBody(t *testing.T) {
+	router := gin.New()
+	// Generous rate so the test isn't slowed down by the limiter itself.
+	router.Use(ThrottledBodyReader(1<<20, 1<<20))
+	router.POST("/test", func(c *gin.Context) {
+		data, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.String(http.StatusInternalServerError, err.Error())
+			return
+		}
+		c.String(http.StatusOK, string(data))
+	})
+
+	resp := performRequest("/test", "hello world", router)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected status %v, got %v", http.StatusOK, resp.Code)
+	}
+	if resp.Body.String() != "hello world" {
+		t.Fatalf("expected body %q, got %q", "hello world", resp.Body.String())
+	}
+}
+
+func TestThrottledBodyReaderPerIPIsolatesLimiters(t *testing.T) {
+	limiters := newPerIPLimiters(1<<20, 1<<20, 2)
+
+	a := limiters.get("1.1.1.1")
+	b := limiters.get("2.2.2.2")
+
+	if a == b {
+		t.Fatalf("expected distinct limiters per IP")
+	}
+	if limiters.get("1.1.1.1") != a {
+		t.Fatalf("expected the same limiter to be reused for a repeat IP")
+	}
+}
+
+func TestThrottledBodyReaderPerIPEvictsLRU(t *testing.T) {
+	limiters := newPerIPLimiters(1<<20, 1<<20, 2)
+
+	first := limiters.get("1.1.1.1")
+	limiters.get("2.2.2.2")
+	limiters.get("3.3.3.3") // evicts 1.1.1.1, the least recently used
+
+	if _, ok := limiters.elements["1.1.1.1"]; ok {
+		t.Fatalf("expected 1.1.1.1 to be evicted")
+	}
+	if got := limiters.get("1.1.1.1"); got == first {
+		t.Fatalf("expected a fresh limiter after eviction")
+	}
+}
+
+func TestThrottledBodyReaderPerIPMiddleware(t *testing.T) {
+	router := gin.New()
+	router.Use(ThrottledBodyReaderPerIP(1<<20, 1<<20, 16))
+	router.POST("/test", func(c *gin.Context) {
+		data, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.String(http.StatusInternalServerError, err.Error())
+			return
+		}
+		c.String(http.StatusOK, string(data))
+	})
+
+	resp := performRequest("/test", "hello world", router)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected status %v, got %v", http.StatusOK, resp.Code)
+	}
+}